@@ -0,0 +1,109 @@
+// Package backoff provides constructors for the DelayFunc that goagain.DoOptions
+// accepts, so callers don't have to hand-roll common backoff strategies.
+//
+// Each constructor returns a func(*goagain.DoResult) time.Duration that can be
+// assigned directly to DoOptions.DelayFunc:
+//
+//	doResult, err := goagain.Do(ctx, work, &goagain.DoOptions{
+//	    DelayFunc: backoff.Exponential(100*time.Millisecond, 2, 10*time.Second),
+//	})
+package backoff
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ConnorCorbin/goagain"
+)
+
+// Constant returns a DelayFunc that always waits d between attempts.
+func Constant(d time.Duration) func(*goagain.DoResult) time.Duration {
+	return func(*goagain.DoResult) time.Duration {
+		return d
+	}
+}
+
+// Linear returns a DelayFunc that waits base + step*(attempt-1) between attempts,
+// capped at max. A max of zero means no cap.
+func Linear(base, step, max time.Duration) func(*goagain.DoResult) time.Duration {
+	return func(currentResult *goagain.DoResult) time.Duration {
+		d := base + step*time.Duration(currentResult.Attempts-1)
+
+		return capAt(d, max)
+	}
+}
+
+// Exponential returns a DelayFunc that waits base*factor^(attempt-1) between
+// attempts, capped at max. A max of zero means no cap.
+func Exponential(base time.Duration, factor float64, max time.Duration) func(*goagain.DoResult) time.Duration {
+	return func(currentResult *goagain.DoResult) time.Duration {
+		d := time.Duration(float64(base) * math.Pow(factor, float64(currentResult.Attempts-1)))
+
+		return capAt(d, max)
+	}
+}
+
+// ExponentialFullJitter returns a DelayFunc implementing AWS's "full jitter"
+// strategy: sleep = rand(0, min(max, base*2^attempt)).
+func ExponentialFullJitter(base, max time.Duration) func(*goagain.DoResult) time.Duration {
+	return func(currentResult *goagain.DoResult) time.Duration {
+		ceiling := capAt(time.Duration(float64(base)*math.Pow(2, float64(currentResult.Attempts))), max)
+		if ceiling <= 0 {
+			return 0
+		}
+
+		return time.Duration(rand.Int63n(int64(ceiling)))
+	}
+}
+
+// DecorrelatedJitter returns a DelayFunc implementing the decorrelated jitter
+// recurrence sleep = min(max, rand(base, prev*3)), seeded with prev=base on the
+// first retry and updated after each call.
+//
+// The returned closure carries the prev state across invocations and is not safe
+// for concurrent reuse across Do calls; construct a new one per call, or per
+// goroutine, that shares a DecorrelatedJitter delay func.
+func DecorrelatedJitter(base, max time.Duration) func(*goagain.DoResult) time.Duration {
+	prev := base
+
+	return func(*goagain.DoResult) time.Duration {
+		upper := prev * 3
+		if upper <= base {
+			upper = base + 1
+		}
+
+		d := capAt(base+time.Duration(rand.Int63n(int64(upper-base))), max)
+		prev = d
+
+		return d
+	}
+}
+
+// WithCap wraps fn so its returned delay never exceeds max. A max of zero means
+// no cap, in which case fn's delay is returned unmodified.
+func WithCap(fn func(*goagain.DoResult) time.Duration, max time.Duration) func(*goagain.DoResult) time.Duration {
+	return func(currentResult *goagain.DoResult) time.Duration {
+		return capAt(fn(currentResult), max)
+	}
+}
+
+// AddJitter wraps fn so its returned delay is multiplied by 1 ± rand*fraction.
+// A fraction of 0.1, for example, jitters the delay by up to 10% in either
+// direction.
+func AddJitter(fn func(*goagain.DoResult) time.Duration, fraction float64) func(*goagain.DoResult) time.Duration {
+	return func(currentResult *goagain.DoResult) time.Duration {
+		d := fn(currentResult)
+		jitter := 1 + fraction*(2*rand.Float64()-1)
+
+		return time.Duration(float64(d) * jitter)
+	}
+}
+
+func capAt(d, max time.Duration) time.Duration {
+	if max > 0 && d > max {
+		return max
+	}
+
+	return d
+}
@@ -0,0 +1,82 @@
+package backoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ConnorCorbin/goagain"
+	"github.com/ConnorCorbin/goagain/backoff"
+)
+
+func TestConstant(t *testing.T) {
+	fn := backoff.Constant(5 * time.Second)
+
+	for attempt := uint(1); attempt <= 3; attempt++ {
+		if got := fn(&goagain.DoResult{Attempts: attempt}); got != 5*time.Second {
+			t.Fatalf("unexpected delay: \ngot: %v\nwant: %v", got, 5*time.Second)
+		}
+	}
+}
+
+func TestLinear(t *testing.T) {
+	fn := backoff.Linear(time.Second, 2*time.Second, 4*time.Second)
+
+	want := []time.Duration{time.Second, 3 * time.Second, 4 * time.Second}
+
+	for i, attempt := range []uint{1, 2, 3} {
+		if got := fn(&goagain.DoResult{Attempts: attempt}); got != want[i] {
+			t.Fatalf("unexpected delay for attempt %d: \ngot: %v\nwant: %v", attempt, got, want[i])
+		}
+	}
+}
+
+func TestExponential(t *testing.T) {
+	fn := backoff.Exponential(time.Second, 2, 10*time.Second)
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}
+
+	for i, attempt := range []uint{1, 2, 3, 4, 5} {
+		if got := fn(&goagain.DoResult{Attempts: attempt}); got != want[i] {
+			t.Fatalf("unexpected delay for attempt %d: \ngot: %v\nwant: %v", attempt, got, want[i])
+		}
+	}
+}
+
+func TestExponentialFullJitter(t *testing.T) {
+	fn := backoff.ExponentialFullJitter(time.Second, 10*time.Second)
+
+	for attempt := uint(1); attempt <= 5; attempt++ {
+		got := fn(&goagain.DoResult{Attempts: attempt})
+		if got < 0 || got > 10*time.Second {
+			t.Fatalf("delay out of bounds for attempt %d: got %v", attempt, got)
+		}
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	fn := backoff.DecorrelatedJitter(time.Second, 10*time.Second)
+
+	for attempt := uint(1); attempt <= 5; attempt++ {
+		got := fn(&goagain.DoResult{Attempts: attempt})
+		if got < time.Second || got > 10*time.Second {
+			t.Fatalf("delay out of bounds for attempt %d: got %v", attempt, got)
+		}
+	}
+}
+
+func TestWithCap(t *testing.T) {
+	fn := backoff.WithCap(backoff.Constant(10*time.Second), 5*time.Second)
+
+	if got := fn(&goagain.DoResult{Attempts: 1}); got != 5*time.Second {
+		t.Fatalf("unexpected delay: \ngot: %v\nwant: %v", got, 5*time.Second)
+	}
+}
+
+func TestAddJitter(t *testing.T) {
+	fn := backoff.AddJitter(backoff.Constant(10*time.Second), 0.1)
+
+	got := fn(&goagain.DoResult{Attempts: 1})
+	if got < 9*time.Second || got > 11*time.Second {
+		t.Fatalf("jittered delay out of bounds: got %v", got)
+	}
+}
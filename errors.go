@@ -0,0 +1,86 @@
+package goagain
+
+import "errors"
+
+// unretryableError marks an error returned from a work function as one that
+// DefaultRetryFunc won't retry. A custom RetryFunc is free to ignore it.
+type unretryableError struct {
+	err error
+}
+
+func (e *unretryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *unretryableError) Unwrap() error {
+	return e.err
+}
+
+// retryableError marks an error returned from a work function as one that
+// DefaultRetryFunc keeps retrying, even if it wraps a cause that would
+// otherwise be classified as unretryable. MaxRetries and a custom RetryFunc
+// still apply.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// Unretryable wraps err so that DefaultRetryFunc stops retrying and returns
+// it immediately. errors.Is and errors.As still see through to err.
+//
+// Unretryable only has an effect through DefaultRetryFunc: it's used whenever
+// DoOptions.RetryFunc is left unset, so a work function can return
+// goagain.Unretryable(err) to bail out immediately without its caller having
+// to write a custom RetryFunc. A caller that sets its own RetryFunc is the
+// sole decision point for that call and can choose to ignore this
+// classification entirely - e.g. to retry despite an Unretryable-wrapped
+// cause from a dependency.
+func Unretryable(err error) error {
+	return &unretryableError{err: err}
+}
+
+// Retryable wraps err so that DefaultRetryFunc keeps retrying it, overriding
+// any Unretryable classification further down the error chain. errors.Is and
+// errors.As still see through to err. As with Unretryable, this only affects
+// calls that leave DoOptions.RetryFunc unset.
+func Retryable(err error) error {
+	return &retryableError{err: err}
+}
+
+// DefaultRetryFunc is the RetryFunc used by Do and DoWithData whenever
+// DoOptions.RetryFunc is left unset (including when options itself is nil).
+// It returns the most recent work error unchanged - stopping the retry loop -
+// if that error was wrapped with Unretryable and wasn't also wrapped with
+// Retryable; otherwise it returns nil, allowing the retry loop to continue.
+func DefaultRetryFunc(currentResult *DoResult) error {
+	if len(currentResult.WorkErrors) == 0 {
+		return nil
+	}
+
+	lastErr := currentResult.WorkErrors[len(currentResult.WorkErrors)-1]
+	if isUnretryable(lastErr) {
+		return lastErr
+	}
+
+	return nil
+}
+
+// isUnretryable reports whether err was wrapped with Unretryable and wasn't
+// also wrapped with Retryable. A Retryable wrapper anywhere in err's chain,
+// inside or outside the Unretryable one, makes err retryable.
+func isUnretryable(err error) bool {
+	var retryable *retryableError
+	if errors.As(err, &retryable) {
+		return false
+	}
+
+	var unretryable *unretryableError
+	return errors.As(err, &unretryable)
+}
@@ -40,6 +40,19 @@
 // the execution of the work function and the start and finish time of the operation. This
 // information can be used to diagnose and troubleshoot issues, as well as to measure the overall
 // performance of the retry mechanism.
+//
+// Example 3: Retry a function that produces a value, using DoWithData.
+//
+//	body, doResult, err := DoWithData(
+//	    context.Background(),
+//	    func() ([]byte, error) {
+//	        return nil, errors.New("retry until success")
+//	    },
+//	    nil,
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
 package goagain
 
 import (
@@ -59,6 +72,12 @@ type DoOptions struct {
 	// an error indicating whether to retry the function. If it returns nil, the
 	// function will be retried. If it returns a non-nil error, the function will not
 	// be retried and the error will be returned to the caller.
+	//
+	// If not specified, DefaultRetryFunc is used, which retries unconditionally
+	// unless the most recent work error was wrapped with Unretryable. Specifying
+	// a RetryFunc makes it the sole decision point for that call - Unretryable
+	// and Retryable no longer have any effect, so a custom RetryFunc is free to
+	// retry despite an Unretryable-wrapped cause if it chooses to.
 	RetryFunc func(currentResult *DoResult) error
 
 	// DelayFunc takes the current result of the function being retried and returns
@@ -66,6 +85,21 @@ type DoOptions struct {
 	// returns a duration less than or equal to zero, the function will be retried
 	// immediately.
 	DelayFunc func(currentResult *DoResult) time.Duration
+
+	// OnRetry, if specified, is invoked after RetryFunc has approved a retry and
+	// after DelayFunc has computed the delay before it, but before the delay is
+	// waited out. It takes the current result of the function being retried and
+	// the duration that will be waited before the next attempt, and is intended
+	// for observing failures - e.g. logging, metrics or tracing - without having
+	// to hijack the retry decision in RetryFunc.
+	OnRetry func(currentResult *DoResult, nextDelay time.Duration)
+
+	// Observers, if specified, are notified of each attempt's lifecycle events -
+	// see the Observer interface. Unlike OnRetry, observers are told about
+	// successful attempts and about the final giveup as well as retried
+	// failures, which makes them a better fit for SLO-quality metrics and
+	// tracing than for ad-hoc logging.
+	Observers []Observer
 }
 
 // DoResult is a result type returned by a GoAgain function.
@@ -94,44 +128,107 @@ var ErrMaxRetries = errors.New("goagain: reached maximum retries")
 // Do retries the provided work function until is succeeds, the maximum number of
 // attempts is reached or is cancelled by the context.
 func Do(ctx context.Context, work func() error, options *DoOptions) (*DoResult, error) {
+	_, result, err := DoWithData(ctx, func() (struct{}, error) {
+		return struct{}{}, work()
+	}, options)
+
+	return result, err
+}
+
+// DoWithData retries the provided work function until it succeeds, the maximum
+// number of attempts is reached or is cancelled by the context, returning the
+// data produced by the final, successful call to work.
+//
+// This is a companion to Do for work functions that produce a value, so callers
+// don't have to close over an outer variable to capture the result:
+//
+//	body, doResult, err := goagain.DoWithData(
+//	    context.Background(),
+//	    func() ([]byte, error) {
+//	        resp, err := http.Get("https://example.com")
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        defer resp.Body.Close()
+//	        return io.ReadAll(resp.Body)
+//	    },
+//	    nil,
+//	)
+func DoWithData[T any](ctx context.Context, work func() (T, error), options *DoOptions) (T, *DoResult, error) {
+	var data T
 	var result DoResult
 	defer func() {
 		result.FinishedAt = time.Now()
 	}()
 
+	var observers []Observer
+	if options != nil {
+		observers = options.Observers
+	}
+
 	result.StartedAt = time.Now()
 
 	for {
 		select {
 		case <-ctx.Done():
-			return &result, ctx.Err()
+			notifyGiveup(ctx, observers, &result, GiveupReasonContextDone)
+			return data, &result, ctx.Err()
 		default:
 			result.Attempts++
+			attemptStartedAt := time.Now()
+			notifyAttemptStarted(ctx, observers, result.Attempts)
 
-			if err := work(); err != nil {
+			d, err := work()
+			if err != nil {
 				result.WorkErrors = append(result.WorkErrors, err)
+				notifyAttemptFailed(ctx, observers, result.Attempts, err)
 
 				if options == nil {
+					if err := DefaultRetryFunc(&result); err != nil {
+						notifyGiveup(ctx, observers, &result, GiveupReasonUnretryable)
+						return data, &result, err
+					}
+
 					continue
 				}
 
 				if result.Attempts == options.MaxRetries {
-					return &result, ErrMaxRetries
+					notifyGiveup(ctx, observers, &result, GiveupReasonMaxRetries)
+					return data, &result, errors.Join(append([]error{ErrMaxRetries}, result.WorkErrors...)...)
 				}
 
+				retryFunc := DefaultRetryFunc
 				if options.RetryFunc != nil {
-					if err := options.RetryFunc(&result); err != nil {
-						return &result, err
+					retryFunc = options.RetryFunc
+				}
+
+				if err := retryFunc(&result); err != nil {
+					reason := GiveupReasonRetryFunc
+					if isUnretryable(err) {
+						reason = GiveupReasonUnretryable
 					}
+
+					notifyGiveup(ctx, observers, &result, reason)
+					return data, &result, err
 				}
 
+				var nextDelay time.Duration
 				if options.DelayFunc != nil {
-					if err := delay(ctx, options.DelayFunc(&result)); err != nil {
-						return &result, err
-					}
+					nextDelay = options.DelayFunc(&result)
+				}
+
+				if options.OnRetry != nil {
+					options.OnRetry(&result, nextDelay)
+				}
+
+				if err := delay(ctx, nextDelay); err != nil {
+					notifyGiveup(ctx, observers, &result, GiveupReasonContextDone)
+					return data, &result, err
 				}
 			} else {
-				return &result, nil
+				notifyAttemptSucceeded(ctx, observers, result.Attempts, time.Since(attemptStartedAt))
+				data = d
+				return data, &result, nil
 			}
 		}
 	}
@@ -3,6 +3,7 @@ package goagain_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -33,6 +34,15 @@ func TestDo(t *testing.T) {
 		assertWorkErrs(tt, r.WorkErrors, []error{errWork, errWork, errWork, errWork, errWork})
 	})
 
+	t.Run("should join all WorkErrors with ErrMaxRetries so both remain reachable via errors.Is", func(tt *testing.T) {
+		_, err := goagain.Do(context.TODO(), func() error { return errWork }, &goagain.DoOptions{
+			MaxRetries: 5,
+		})
+
+		assertErr(tt, err, goagain.ErrMaxRetries)
+		assertErr(tt, err, errWork)
+	})
+
 	t.Run("should have correct DoResult when retry function returns an error", func(tt *testing.T) {
 		r, err := goagain.Do(context.TODO(), func() error { return errWork }, &goagain.DoOptions{
 			MaxRetries: 5,
@@ -98,6 +108,233 @@ func TestDo(t *testing.T) {
 	})
 }
 
+func TestDoUnretryable(t *testing.T) {
+	t.Run("should stop immediately when work returns an Unretryable error", func(tt *testing.T) {
+		attempts := 0
+
+		r, err := goagain.Do(context.TODO(), func() error {
+			attempts++
+			return goagain.Unretryable(errWork)
+		}, nil)
+
+		if !errors.Is(err, errWork) {
+			tt.Fatalf("unexpected error: \ngot: %v\nwant it to wrap: %v", err, errWork)
+		}
+		assertAttempts(tt, r.Attempts, 1)
+		if attempts != 1 {
+			tt.Fatalf("unexpected number of work invocations: \ngot: %v\nwant: %v", attempts, 1)
+		}
+	})
+
+	t.Run("should keep retrying when Retryable overrides a wrapped Unretryable error", func(tt *testing.T) {
+		attempts := 0
+
+		r, err := goagain.Do(context.TODO(), func() error {
+			attempts++
+			if attempts < 3 {
+				return goagain.Retryable(goagain.Unretryable(errWork))
+			}
+			return nil
+		}, nil)
+
+		assertErr(tt, err, nil)
+		assertAttempts(tt, r.Attempts, 3)
+	})
+
+	t.Run("should let a custom RetryFunc retry despite a wrapped Unretryable error", func(tt *testing.T) {
+		attempts := 0
+
+		r, err := goagain.Do(context.TODO(), func() error {
+			attempts++
+			if attempts < 3 {
+				return goagain.Unretryable(errWork)
+			}
+			return nil
+		}, &goagain.DoOptions{
+			RetryFunc: func(currentResult *goagain.DoResult) error { return nil },
+		})
+
+		assertErr(tt, err, nil)
+		assertAttempts(tt, r.Attempts, 3)
+	})
+}
+
+func TestDoOnRetry(t *testing.T) {
+	t.Run("should invoke OnRetry after RetryFunc and DelayFunc, before the delay is waited out", func(tt *testing.T) {
+		var calls []string
+
+		r, err := goagain.Do(context.TODO(), func() error { return errWork }, &goagain.DoOptions{
+			MaxRetries: 3,
+			RetryFunc: func(currentResult *goagain.DoResult) error {
+				calls = append(calls, "retry")
+				return nil
+			},
+			DelayFunc: func(currentResult *goagain.DoResult) time.Duration {
+				calls = append(calls, "delay")
+				return 0
+			},
+			OnRetry: func(currentResult *goagain.DoResult, nextDelay time.Duration) {
+				calls = append(calls, "onretry")
+			},
+		})
+
+		assertErr(tt, err, goagain.ErrMaxRetries)
+		assertAttempts(tt, r.Attempts, 3)
+
+		want := []string{"retry", "delay", "onretry", "retry", "delay", "onretry"}
+		if len(calls) != len(want) {
+			tt.Fatalf("unexpected call order: \ngot: %v\nwant: %v", calls, want)
+		}
+		for i := range calls {
+			if calls[i] != want[i] {
+				tt.Fatalf("unexpected call order: \ngot: %v\nwant: %v", calls, want)
+			}
+		}
+	})
+
+	t.Run("should pass the delay computed by DelayFunc to OnRetry", func(tt *testing.T) {
+		var gotDelay time.Duration
+
+		_, err := goagain.Do(context.TODO(), func() error { return errWork }, &goagain.DoOptions{
+			MaxRetries: 2,
+			DelayFunc: func(currentResult *goagain.DoResult) time.Duration {
+				return 42 * time.Millisecond
+			},
+			OnRetry: func(currentResult *goagain.DoResult, nextDelay time.Duration) {
+				gotDelay = nextDelay
+			},
+		})
+
+		assertErr(tt, err, goagain.ErrMaxRetries)
+
+		if gotDelay != 42*time.Millisecond {
+			tt.Fatalf("unexpected delay: \ngot: %v\nwant: %v", gotDelay, 42*time.Millisecond)
+		}
+	})
+
+	t.Run("should have correct DoResult when context is cancelled during the delay after OnRetry", func(tt *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		r, err := goagain.Do(ctx, func() error { return errWork }, &goagain.DoOptions{
+			OnRetry: func(currentResult *goagain.DoResult, nextDelay time.Duration) {
+				if currentResult.Attempts == 3 {
+					cancel()
+				}
+			},
+			DelayFunc: func(currentResult *goagain.DoResult) time.Duration {
+				return shortDuration
+			},
+		})
+
+		assertErr(tt, err, ctx.Err())
+		assertAttempts(tt, r.Attempts, 3)
+	})
+}
+
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) AttemptStarted(ctx context.Context, attempt uint) {
+	o.events = append(o.events, fmt.Sprintf("started:%d", attempt))
+}
+
+func (o *recordingObserver) AttemptFailed(ctx context.Context, attempt uint, err error) {
+	o.events = append(o.events, fmt.Sprintf("failed:%d", attempt))
+}
+
+func (o *recordingObserver) AttemptSucceeded(ctx context.Context, attempt uint, elapsed time.Duration) {
+	o.events = append(o.events, fmt.Sprintf("succeeded:%d", attempt))
+}
+
+func (o *recordingObserver) Giveup(ctx context.Context, result *goagain.DoResult, reason goagain.GiveupReason) {
+	o.events = append(o.events, fmt.Sprintf("giveup:%s", reason))
+}
+
+func TestDoObservers(t *testing.T) {
+	t.Run("should notify observers of every attempt and the final success", func(tt *testing.T) {
+		attempts := 0
+		observer := &recordingObserver{}
+
+		_, err := goagain.Do(context.TODO(), func() error {
+			attempts++
+			if attempts < 3 {
+				return errWork
+			}
+			return nil
+		}, &goagain.DoOptions{
+			MaxRetries: 5,
+			Observers:  []goagain.Observer{observer},
+		})
+
+		assertErr(tt, err, nil)
+
+		want := []string{"started:1", "failed:1", "started:2", "failed:2", "started:3", "succeeded:3"}
+		if len(observer.events) != len(want) {
+			tt.Fatalf("unexpected events: \ngot: %v\nwant: %v", observer.events, want)
+		}
+		for i := range want {
+			if observer.events[i] != want[i] {
+				tt.Fatalf("unexpected events: \ngot: %v\nwant: %v", observer.events, want)
+			}
+		}
+	})
+
+	t.Run("should notify observers of a giveup when maximum retries is reached", func(tt *testing.T) {
+		observer := &recordingObserver{}
+
+		_, err := goagain.Do(context.TODO(), func() error { return errWork }, &goagain.DoOptions{
+			MaxRetries: 2,
+			Observers:  []goagain.Observer{observer},
+		})
+
+		assertErr(tt, err, goagain.ErrMaxRetries)
+
+		last := observer.events[len(observer.events)-1]
+		if last != "giveup:max_retries" {
+			tt.Fatalf("unexpected final event: \ngot: %v\nwant: %v", last, "giveup:max_retries")
+		}
+	})
+}
+
+func TestDoWithData(t *testing.T) {
+	t.Run("should return the data produced by the successful attempt", func(tt *testing.T) {
+		attempts := 0
+
+		data, r, err := goagain.DoWithData(context.TODO(), func() (string, error) {
+			attempts++
+			if attempts < 3 {
+				return "", errWork
+			}
+
+			return "ok", nil
+		}, &goagain.DoOptions{
+			MaxRetries: 5,
+		})
+
+		assertErr(tt, err, nil)
+		assertAttempts(tt, r.Attempts, 3)
+		assertWorkErrs(tt, r.WorkErrors, []error{errWork, errWork})
+
+		if data != "ok" {
+			tt.Fatalf("unexpected data: \ngot: %v\nwant: %v", data, "ok")
+		}
+	})
+
+	t.Run("should return the zero value when maximum retries is reached", func(tt *testing.T) {
+		data, r, err := goagain.DoWithData(context.TODO(), func() (int, error) { return 0, errWork }, &goagain.DoOptions{
+			MaxRetries: 5,
+		})
+
+		assertErr(tt, err, goagain.ErrMaxRetries)
+		assertAttempts(tt, r.Attempts, 5)
+
+		if data != 0 {
+			tt.Fatalf("unexpected data: \ngot: %v\nwant: %v", data, 0)
+		}
+	})
+}
+
 func assertAttempts(t *testing.T, got uint, want uint) {
 	if got != want {
 		t.Fatalf("unexpected attempts: \ngot: %v\nwant: %v", got, want)
@@ -0,0 +1,112 @@
+// Package httpx provides goagain helpers for retrying HTTP calls, including
+// honoring the Retry-After header that servers use to signal backpressure.
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ConnorCorbin/goagain"
+)
+
+// RetryAfterDelay returns a DelayFunc that honors the Retry-After header of
+// the *http.Response returned by getResp, supporting both the delta-seconds
+// and HTTP-date forms from RFC 7231 §7.1.3. If getResp returns nil, the
+// response has no Retry-After header, or the header can't be parsed, the
+// returned func falls back to fallback.
+//
+// getResp is called fresh on every attempt, so it should read whatever
+// variable the work function reassigns each time it runs - a plain
+// *http.Response passed in directly would only ever see the response from
+// the moment DoOptions was built, before Do's first attempt:
+//
+//	var resp *http.Response
+//	doResult, err := goagain.Do(ctx, func() error {
+//	    var err error
+//	    resp, err = client.Do(req)
+//	    ...
+//	    return err
+//	}, &goagain.DoOptions{
+//	    DelayFunc: httpx.RetryAfterDelay(func() *http.Response { return resp }, backoff.Exponential(time.Second, 2, 0)),
+//	})
+func RetryAfterDelay(getResp func() *http.Response, fallback func(*goagain.DoResult) time.Duration) func(*goagain.DoResult) time.Duration {
+	return func(currentResult *goagain.DoResult) time.Duration {
+		if d, ok := parseRetryAfter(getResp()); ok {
+			return d
+		}
+
+		if fallback != nil {
+			return fallback(currentResult)
+		}
+
+		return 0
+	}
+}
+
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		d := time.Until(at)
+		if d < 0 {
+			d = 0
+		}
+
+		return d, true
+	}
+
+	return 0, false
+}
+
+// IsRetryableStatus reports whether code is one of the HTTP status codes that
+// typically indicate a transient failure worth retrying: 408, 429, 502, 503
+// and 504.
+func IsRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryFunc returns a goagain RetryFunc that stops retrying once the status
+// code of the *http.Response returned by getResp is no longer one
+// IsRetryableStatus considers transient, returning an error describing the
+// final status code.
+//
+// As with RetryAfterDelay, getResp is called fresh on every attempt and
+// should read whatever variable the work function reassigns each time it
+// runs.
+func RetryFunc(getResp func() *http.Response) func(*goagain.DoResult) error {
+	return func(*goagain.DoResult) error {
+		resp := getResp()
+		if resp == nil {
+			return nil
+		}
+
+		if IsRetryableStatus(resp.StatusCode) {
+			return nil
+		}
+
+		return fmt.Errorf("httpx: non-retryable status code %d", resp.StatusCode)
+	}
+}
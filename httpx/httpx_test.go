@@ -0,0 +1,143 @@
+package httpx_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ConnorCorbin/goagain"
+	"github.com/ConnorCorbin/goagain/httpx"
+)
+
+var errWork = errors.New("work error")
+
+func TestRetryAfterDelay(t *testing.T) {
+	t.Run("should use delta-seconds form when present", func(tt *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+
+		fn := httpx.RetryAfterDelay(func() *http.Response { return resp }, nil)
+		if got := fn(&goagain.DoResult{}); got != 5*time.Second {
+			tt.Fatalf("unexpected delay: \ngot: %v\nwant: %v", got, 5*time.Second)
+		}
+	})
+
+	t.Run("should fall back when header is absent", func(tt *testing.T) {
+		fallback := func(*goagain.DoResult) time.Duration { return 2 * time.Second }
+
+		fn := httpx.RetryAfterDelay(func() *http.Response { return nil }, fallback)
+		if got := fn(&goagain.DoResult{}); got != 2*time.Second {
+			tt.Fatalf("unexpected delay: \ngot: %v\nwant: %v", got, 2*time.Second)
+		}
+	})
+
+	t.Run("should fall back when header is unparseable", func(tt *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"not-a-value"}}}
+		fallback := func(*goagain.DoResult) time.Duration { return 3 * time.Second }
+
+		fn := httpx.RetryAfterDelay(func() *http.Response { return resp }, fallback)
+		if got := fn(&goagain.DoResult{}); got != 3*time.Second {
+			tt.Fatalf("unexpected delay: \ngot: %v\nwant: %v", got, 3*time.Second)
+		}
+	})
+
+	t.Run("should see the work function's latest response across attempts of a real Do loop", func(tt *testing.T) {
+		var resp *http.Response
+		responses := []*http.Response{
+			{Header: http.Header{"Retry-After": []string{"1"}}},
+			{Header: http.Header{"Retry-After": []string{"0"}}},
+			{Header: http.Header{}},
+		}
+
+		var gotDelays []time.Duration
+		attempt := 0
+
+		_, err := goagain.Do(context.TODO(), func() error {
+			resp = responses[attempt]
+			attempt++
+			if attempt < len(responses) {
+				return errWork
+			}
+			return nil
+		}, &goagain.DoOptions{
+			MaxRetries: uint(len(responses)),
+			DelayFunc: httpx.RetryAfterDelay(func() *http.Response { return resp }, func(*goagain.DoResult) time.Duration {
+				return 0
+			}),
+			OnRetry: func(currentResult *goagain.DoResult, nextDelay time.Duration) {
+				gotDelays = append(gotDelays, nextDelay)
+			},
+		})
+
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []time.Duration{time.Second, 0}
+		if len(gotDelays) != len(want) {
+			tt.Fatalf("unexpected delays: \ngot: %v\nwant: %v", gotDelays, want)
+		}
+		for i := range want {
+			if gotDelays[i] != want[i] {
+				tt.Fatalf("unexpected delays: \ngot: %v\nwant: %v", gotDelays, want)
+			}
+		}
+	})
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	retryable := []int{http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	for _, code := range retryable {
+		if !httpx.IsRetryableStatus(code) {
+			t.Fatalf("expected status %d to be retryable", code)
+		}
+	}
+
+	notRetryable := []int{http.StatusOK, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError}
+	for _, code := range notRetryable {
+		if httpx.IsRetryableStatus(code) {
+			t.Fatalf("expected status %d to not be retryable", code)
+		}
+	}
+}
+
+func TestRetryFunc(t *testing.T) {
+	t.Run("should return nil for a retryable status", func(tt *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusServiceUnavailable}
+
+		if err := httpx.RetryFunc(func() *http.Response { return resp })(&goagain.DoResult{}); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("should return an error for a non-retryable status", func(tt *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusBadRequest}
+
+		if err := httpx.RetryFunc(func() *http.Response { return resp })(&goagain.DoResult{}); err == nil {
+			tt.Fatal("expected an error, got nil")
+		}
+	})
+
+	t.Run("should see the work function's latest response across attempts of a real Do loop", func(tt *testing.T) {
+		var resp *http.Response
+		statuses := []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusBadRequest}
+		attempt := 0
+
+		_, err := goagain.Do(context.TODO(), func() error {
+			resp = &http.Response{StatusCode: statuses[attempt]}
+			attempt++
+			return errWork
+		}, &goagain.DoOptions{
+			MaxRetries: 10,
+			RetryFunc:  httpx.RetryFunc(func() *http.Response { return resp }),
+		})
+
+		if err == nil {
+			tt.Fatal("expected an error, got nil")
+		}
+		if attempt != len(statuses) {
+			tt.Fatalf("unexpected number of attempts: \ngot: %v\nwant: %v", attempt, len(statuses))
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package goagain
+
+import (
+	"context"
+	"time"
+)
+
+// GiveupReason describes why a GoAgain function stopped retrying without
+// ultimately succeeding.
+type GiveupReason string
+
+const (
+	// GiveupReasonMaxRetries means MaxRetries was reached.
+	GiveupReasonMaxRetries GiveupReason = "max_retries"
+
+	// GiveupReasonUnretryable means the work function returned an error
+	// wrapped with Unretryable.
+	GiveupReasonUnretryable GiveupReason = "unretryable"
+
+	// GiveupReasonRetryFunc means RetryFunc rejected the retry.
+	GiveupReasonRetryFunc GiveupReason = "retry_func"
+
+	// GiveupReasonContextDone means the context was cancelled or its
+	// deadline was exceeded.
+	GiveupReasonContextDone GiveupReason = "context_done"
+)
+
+// Observer is notified of the lifecycle of each attempt made by a GoAgain
+// function. It's intended for metrics and tracing integrations that need
+// SLO-quality visibility without re-implementing timing and logging around
+// RetryFunc or OnRetry - see the goagain/otelobserver and goagain/promobserver
+// packages for ready-made implementations.
+//
+// Implementations must be safe for concurrent use, since a shared Observer is
+// typically attached to many Do calls.
+type Observer interface {
+	// AttemptStarted is called immediately before the work function is
+	// invoked for the given attempt. The initial attempt is numbered 1.
+	AttemptStarted(ctx context.Context, attempt uint)
+
+	// AttemptFailed is called after the work function returns a non-nil
+	// error for the given attempt.
+	AttemptFailed(ctx context.Context, attempt uint, err error)
+
+	// AttemptSucceeded is called after the work function returns nil for the
+	// given attempt, with the time elapsed since that attempt started.
+	AttemptSucceeded(ctx context.Context, attempt uint, elapsed time.Duration)
+
+	// Giveup is called once, in place of a further AttemptStarted, when a
+	// GoAgain function stops retrying without success.
+	Giveup(ctx context.Context, result *DoResult, reason GiveupReason)
+}
+
+// NoopObserver is an Observer whose methods do nothing. It's useful as an
+// embedded default for partial Observer implementations.
+type NoopObserver struct{}
+
+func (NoopObserver) AttemptStarted(ctx context.Context, attempt uint) {}
+
+func (NoopObserver) AttemptFailed(ctx context.Context, attempt uint, err error) {}
+
+func (NoopObserver) AttemptSucceeded(ctx context.Context, attempt uint, elapsed time.Duration) {}
+
+func (NoopObserver) Giveup(ctx context.Context, result *DoResult, reason GiveupReason) {}
+
+func notifyAttemptStarted(ctx context.Context, observers []Observer, attempt uint) {
+	for _, o := range observers {
+		o.AttemptStarted(ctx, attempt)
+	}
+}
+
+func notifyAttemptFailed(ctx context.Context, observers []Observer, attempt uint, err error) {
+	for _, o := range observers {
+		o.AttemptFailed(ctx, attempt, err)
+	}
+}
+
+func notifyAttemptSucceeded(ctx context.Context, observers []Observer, attempt uint, elapsed time.Duration) {
+	for _, o := range observers {
+		o.AttemptSucceeded(ctx, attempt, elapsed)
+	}
+}
+
+func notifyGiveup(ctx context.Context, observers []Observer, result *DoResult, reason GiveupReason) {
+	for _, o := range observers {
+		o.Giveup(ctx, result, reason)
+	}
+}
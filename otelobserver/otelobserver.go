@@ -0,0 +1,61 @@
+// Package otelobserver provides a goagain.Observer that annotates the
+// OpenTelemetry span already present in the context with an event and,
+// where relevant, an attribute per retry attempt.
+//
+// It doesn't start its own span, since a goagain.Do call is typically one
+// step within a larger traced operation; instead it records against
+// trace.SpanFromContext(ctx), which is a no-op span if the caller isn't
+// already tracing. Callers that want attempts to be individually traced
+// should start their own span inside the work function.
+package otelobserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ConnorCorbin/goagain"
+)
+
+// Observer is a goagain.Observer that emits OpenTelemetry span events and
+// attributes. The zero value is ready to use.
+type Observer struct{}
+
+// New returns an Observer ready to be attached to DoOptions.Observers.
+func New() *Observer {
+	return &Observer{}
+}
+
+func (o *Observer) AttemptStarted(ctx context.Context, attempt uint) {
+	trace.SpanFromContext(ctx).AddEvent("goagain.attempt_started", trace.WithAttributes(
+		attribute.Int64("goagain.attempt", int64(attempt)),
+	))
+}
+
+func (o *Observer) AttemptFailed(ctx context.Context, attempt uint, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("goagain.attempt_failed", trace.WithAttributes(
+		attribute.Int64("goagain.attempt", int64(attempt)),
+	))
+	span.RecordError(err)
+}
+
+func (o *Observer) AttemptSucceeded(ctx context.Context, attempt uint, elapsed time.Duration) {
+	trace.SpanFromContext(ctx).AddEvent("goagain.attempt_succeeded", trace.WithAttributes(
+		attribute.Int64("goagain.attempt", int64(attempt)),
+		attribute.Int64("goagain.elapsed_ms", elapsed.Milliseconds()),
+	))
+}
+
+func (o *Observer) Giveup(ctx context.Context, result *goagain.DoResult, reason goagain.GiveupReason) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("goagain.giveup", trace.WithAttributes(
+		attribute.Int64("goagain.attempts", int64(result.Attempts)),
+		attribute.String("goagain.giveup_reason", string(reason)),
+	))
+	span.SetStatus(codes.Error, fmt.Sprintf("goagain: gave up after %d attempts: %s", result.Attempts, reason))
+}
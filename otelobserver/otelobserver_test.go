@@ -0,0 +1,23 @@
+package otelobserver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ConnorCorbin/goagain"
+	"github.com/ConnorCorbin/goagain/otelobserver"
+)
+
+func TestObserver(t *testing.T) {
+	o := otelobserver.New()
+	ctx := context.Background()
+
+	// With no tracer provider configured, trace.SpanFromContext(ctx) returns
+	// a no-op span, so these calls only need to not panic.
+	o.AttemptStarted(ctx, 1)
+	o.AttemptFailed(ctx, 1, errors.New("work error"))
+	o.AttemptSucceeded(ctx, 2, time.Millisecond)
+	o.Giveup(ctx, &goagain.DoResult{Attempts: 2}, goagain.GiveupReasonMaxRetries)
+}
@@ -0,0 +1,63 @@
+// Package promobserver provides a goagain.Observer that records Prometheus
+// metrics for retry attempts: an attempt counter labeled by outcome, a
+// latency histogram of successful attempts and a giveup counter labeled by
+// reason. Failed attempts are counted but their latency isn't recorded.
+package promobserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ConnorCorbin/goagain"
+)
+
+// Observer is a goagain.Observer that records Prometheus metrics. Construct
+// one with New, which registers its metrics against reg.
+type Observer struct {
+	attemptsTotal  *prometheus.CounterVec
+	attemptLatency prometheus.Histogram
+	giveupsTotal   *prometheus.CounterVec
+}
+
+// New creates an Observer and registers its metrics against reg.
+func New(reg prometheus.Registerer) (*Observer, error) {
+	o := &Observer{
+		attemptsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "goagain_attempts_total",
+			Help: "Total number of goagain work attempts, labeled by outcome.",
+		}, []string{"outcome"}),
+		attemptLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name: "goagain_attempt_duration_seconds",
+			Help: "Duration of successful goagain work attempts.",
+		}),
+		giveupsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "goagain_giveups_total",
+			Help: "Total number of times goagain gave up, labeled by reason.",
+		}, []string{"reason"}),
+	}
+
+	for _, c := range []prometheus.Collector{o.attemptsTotal, o.attemptLatency, o.giveupsTotal} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
+
+func (o *Observer) AttemptStarted(ctx context.Context, attempt uint) {}
+
+func (o *Observer) AttemptFailed(ctx context.Context, attempt uint, err error) {
+	o.attemptsTotal.WithLabelValues("failure").Inc()
+}
+
+func (o *Observer) AttemptSucceeded(ctx context.Context, attempt uint, elapsed time.Duration) {
+	o.attemptsTotal.WithLabelValues("success").Inc()
+	o.attemptLatency.Observe(elapsed.Seconds())
+}
+
+func (o *Observer) Giveup(ctx context.Context, result *goagain.DoResult, reason goagain.GiveupReason) {
+	o.giveupsTotal.WithLabelValues(string(reason)).Inc()
+}
@@ -0,0 +1,37 @@
+package promobserver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ConnorCorbin/goagain"
+	"github.com/ConnorCorbin/goagain/promobserver"
+)
+
+func TestObserver(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	o, err := promobserver.New(reg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	o.AttemptStarted(ctx, 1)
+	o.AttemptFailed(ctx, 1, errors.New("work error"))
+	o.AttemptSucceeded(ctx, 2, time.Millisecond)
+	o.Giveup(ctx, &goagain.DoResult{Attempts: 2}, goagain.GiveupReasonMaxRetries)
+
+	metricFamilies, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(metricFamilies) == 0 {
+		t.Fatal("expected metrics to be registered")
+	}
+}
@@ -0,0 +1,129 @@
+package goagain
+
+import (
+	"context"
+	"time"
+)
+
+// Retrier captures a retry policy once so it can be reused across many calls,
+// instead of rebuilding a DoOptions for every call site. Unlike DoOptions, a
+// Retrier is safe for concurrent use by multiple goroutines, as long as the
+// RetrierOptions it was built from don't themselves carry shared mutable
+// state - see backoff.DecorrelatedJitter, for example, which isn't safe to
+// reuse concurrently across Do calls.
+//
+// Because work needs access to the context for the current attempt (in
+// particular when WithTimeoutPerAttempt is used), Retrier's work functions
+// take a context.Context rather than the plain func() error that Do and
+// DoWithData accept.
+type Retrier struct {
+	maxRetries        uint
+	delayFunc         func(currentResult *DoResult) time.Duration
+	retryIf           func(err error) bool
+	onRetry           func(currentResult *DoResult, nextDelay time.Duration)
+	timeoutPerAttempt time.Duration
+}
+
+// RetrierOption configures a Retrier returned by NewRetrier.
+type RetrierOption func(*Retrier)
+
+// WithMaxRetries sets the maximum number of times to retry the function
+// before giving up, as DoOptions.MaxRetries does.
+func WithMaxRetries(maxRetries uint) RetrierOption {
+	return func(r *Retrier) {
+		r.maxRetries = maxRetries
+	}
+}
+
+// WithBackoff sets the DelayFunc used to wait between attempts, as
+// DoOptions.DelayFunc does. It composes naturally with the goagain/backoff
+// constructors.
+func WithBackoff(delayFunc func(currentResult *DoResult) time.Duration) RetrierOption {
+	return func(r *Retrier) {
+		r.delayFunc = delayFunc
+	}
+}
+
+// WithRetryIf sets a predicate that's consulted with the most recent work
+// error after every failed attempt. If it returns false, the Retrier stops
+// and returns that error, instead of continuing to retry.
+func WithRetryIf(retryIf func(err error) bool) RetrierOption {
+	return func(r *Retrier) {
+		r.retryIf = retryIf
+	}
+}
+
+// WithOnRetry sets the OnRetry hook, as DoOptions.OnRetry does.
+func WithOnRetry(onRetry func(currentResult *DoResult, nextDelay time.Duration)) RetrierOption {
+	return func(r *Retrier) {
+		r.onRetry = onRetry
+	}
+}
+
+// WithTimeoutPerAttempt bounds each call to work with its own
+// context.WithTimeout, derived from the context passed to Do or
+// RetrierDoWithData. A duration of zero, the default, leaves attempts
+// unbounded.
+func WithTimeoutPerAttempt(d time.Duration) RetrierOption {
+	return func(r *Retrier) {
+		r.timeoutPerAttempt = d
+	}
+}
+
+// NewRetrier builds a Retrier from the given options.
+func NewRetrier(opts ...RetrierOption) *Retrier {
+	var r Retrier
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	return &r
+}
+
+// Do retries work using the policy r was built with, until it succeeds, the
+// maximum number of attempts is reached or is cancelled by the context.
+func (r *Retrier) Do(ctx context.Context, work func(ctx context.Context) error) (*DoResult, error) {
+	_, result, err := RetrierDoWithData(ctx, r, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, work(ctx)
+	})
+
+	return result, err
+}
+
+// RetrierDoWithData retries work using the policy r was built with, until it
+// succeeds, the maximum number of attempts is reached or is cancelled by the
+// context, returning the data produced by the final, successful call to
+// work.
+//
+// This is a package-level function, rather than a method on Retrier, because
+// Go methods can't take their own type parameters.
+func RetrierDoWithData[T any](ctx context.Context, r *Retrier, work func(ctx context.Context) (T, error)) (T, *DoResult, error) {
+	options := &DoOptions{
+		MaxRetries: r.maxRetries,
+		DelayFunc:  r.delayFunc,
+		OnRetry:    r.onRetry,
+	}
+
+	if r.retryIf != nil {
+		options.RetryFunc = func(currentResult *DoResult) error {
+			lastErr := currentResult.WorkErrors[len(currentResult.WorkErrors)-1]
+			if r.retryIf(lastErr) {
+				return nil
+			}
+
+			return lastErr
+		}
+	}
+
+	return DoWithData(ctx, func() (T, error) {
+		attemptCtx := ctx
+
+		if r.timeoutPerAttempt > 0 {
+			var cancel context.CancelFunc
+			attemptCtx, cancel = context.WithTimeout(ctx, r.timeoutPerAttempt)
+			defer cancel()
+		}
+
+		return work(attemptCtx)
+	}, options)
+}
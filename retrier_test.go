@@ -0,0 +1,102 @@
+package goagain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ConnorCorbin/goagain"
+)
+
+func TestRetrier(t *testing.T) {
+	t.Run("should have correct DoResult when first attempt is successful", func(tt *testing.T) {
+		r := goagain.NewRetrier(goagain.WithMaxRetries(5))
+
+		result, err := r.Do(context.TODO(), func(context.Context) error { return nil })
+
+		assertErr(tt, err, nil)
+		assertAttempts(tt, result.Attempts, 1)
+	})
+
+	t.Run("should have correct DoResult when maximum retries is reached", func(tt *testing.T) {
+		r := goagain.NewRetrier(goagain.WithMaxRetries(5))
+
+		result, err := r.Do(context.TODO(), func(context.Context) error { return errWork })
+
+		assertErr(tt, err, goagain.ErrMaxRetries)
+		assertAttempts(tt, result.Attempts, 5)
+	})
+
+	t.Run("should stop retrying when RetryIf returns false", func(tt *testing.T) {
+		r := goagain.NewRetrier(
+			goagain.WithMaxRetries(5),
+			goagain.WithRetryIf(func(err error) bool { return !errors.Is(err, errEarlyExit) }),
+		)
+
+		result, err := r.Do(context.TODO(), func(context.Context) error { return errEarlyExit })
+
+		assertErr(tt, err, errEarlyExit)
+		assertAttempts(tt, result.Attempts, 1)
+	})
+
+	t.Run("should be reusable across concurrent Do calls", func(tt *testing.T) {
+		r := goagain.NewRetrier(
+			goagain.WithMaxRetries(3),
+			goagain.WithBackoff(func(*goagain.DoResult) time.Duration { return time.Millisecond }),
+		)
+
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			go func() {
+				_, err := r.Do(context.TODO(), func(context.Context) error { return errWork })
+				errs <- err
+			}()
+		}
+
+		for i := 0; i < 10; i++ {
+			if err := <-errs; !errors.Is(err, goagain.ErrMaxRetries) {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	t.Run("should bound each attempt with WithTimeoutPerAttempt", func(tt *testing.T) {
+		r := goagain.NewRetrier(
+			goagain.WithMaxRetries(2),
+			goagain.WithTimeoutPerAttempt(10*time.Millisecond),
+		)
+
+		result, err := r.Do(context.TODO(), func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		assertErr(tt, err, goagain.ErrMaxRetries)
+		assertErr(tt, err, context.DeadlineExceeded)
+		assertAttempts(tt, result.Attempts, 2)
+	})
+}
+
+func TestRetrierDoWithData(t *testing.T) {
+	t.Run("should return the data produced by the successful attempt", func(tt *testing.T) {
+		r := goagain.NewRetrier(goagain.WithMaxRetries(5))
+
+		attempts := 0
+		data, result, err := goagain.RetrierDoWithData(context.TODO(), r, func(context.Context) (string, error) {
+			attempts++
+			if attempts < 2 {
+				return "", errWork
+			}
+
+			return "ok", nil
+		})
+
+		assertErr(tt, err, nil)
+		assertAttempts(tt, result.Attempts, 2)
+
+		if data != "ok" {
+			tt.Fatalf("unexpected data: \ngot: %v\nwant: %v", data, "ok")
+		}
+	})
+}